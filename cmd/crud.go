@@ -0,0 +1,459 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// withCrudFlag backs 'shiroha new --with-crud'.
+var withCrudFlag string
+
+// validEntityName matches names that are safe to splice into Go
+// identifiers, file names and route paths: a letter followed by letters
+// or digits.
+var validEntityName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+
+// writeCrudScaffold generates a full vertical slice (model, repository,
+// service, handler) for entity plus a Wire provider set wiring them
+// together, and a SQLite-backed internal/database bootstrap to back it.
+func writeCrudScaffold(projectDir, entity string) error {
+	if entity == "" {
+		return nil
+	}
+
+	if !validEntityName.MatchString(entity) {
+		return fmt.Errorf("invalid --with-crud entity name %q: must start with a letter and contain only letters and digits", entity)
+	}
+
+	title := exportedName(strings.ToLower(entity[:1]) + entity[1:])
+	lower := strings.ToLower(title[:1]) + title[1:]
+	module := filepath.ToSlash(projectDir)
+
+	replacer := strings.NewReplacer(
+		"{{Module}}", module,
+		"{{Title}}", title,
+		"{{title}}", lower,
+		"{{lowertag}}", strings.ToLower(title),
+	)
+
+	files := map[string]string{
+		filepath.Join(projectDir, "internal", "model", strings.ToLower(title)+".go"):                 crudModelTemplate,
+		filepath.Join(projectDir, "internal", "repository", strings.ToLower(title)+"_repository.go"): crudRepositoryTemplate,
+		filepath.Join(projectDir, "internal", "service", strings.ToLower(title)+"_service.go"):       crudServiceTemplate,
+		filepath.Join(projectDir, "internal", "handler", strings.ToLower(title)+"_handler.go"):       crudHandlerTemplate,
+		filepath.Join(projectDir, "internal", "database", "database.go"):                             crudDatabaseTemplate,
+		filepath.Join(projectDir, "cmd", "wire.go"):                                                  crudWireTemplate,
+	}
+
+	for path, tmpl := range files {
+		if err := os.WriteFile(path, []byte(replacer.Replace(tmpl)), 0644); err != nil {
+			return err
+		}
+	}
+
+	if err := injectCrudRouter(projectDir, module, title, lower); err != nil {
+		return err
+	}
+	if err := injectCrudServer(projectDir, module, title, lower); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// injectCrudRouter wires the generated handler into InitRouter: it adds
+// the handler import, threads a *handler.{{Title}}Handler parameter
+// through InitRouter, and registers the five CRUD routes, the same way
+// 'shiroha proto inject' patches main_router.go for gRPC services.
+func injectCrudRouter(projectDir, moduleName, title, lower string) error {
+	path := filepath.Join(projectDir, "internal", "router", "main_router.go")
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read internal/router/main_router.go: %w", err)
+	}
+	src := string(content)
+	tag := strings.ToLower(title)
+
+	importAnchor := "\"github.com/gin-gonic/gin\"\n)"
+	if !strings.Contains(src, importAnchor) {
+		return fmt.Errorf("could not locate gin import in internal/router/main_router.go")
+	}
+	src = strings.Replace(src, importAnchor,
+		fmt.Sprintf("\"github.com/gin-gonic/gin\"\n\n    \"%s/internal/handler\"\n)", moduleName), 1)
+
+	sigAnchor := "func InitRouter() *gin.Engine {"
+	if !strings.Contains(src, sigAnchor) {
+		return fmt.Errorf("could not locate InitRouter signature in internal/router/main_router.go")
+	}
+	src = strings.Replace(src, sigAnchor,
+		fmt.Sprintf("func InitRouter(%sHandler *handler.%sHandler) *gin.Engine {", lower, title), 1)
+
+	var routes strings.Builder
+	routes.WriteString(fmt.Sprintf("    r.POST(\"/%s\", %sHandler.Create%s)\n", tag, lower, title))
+	routes.WriteString(fmt.Sprintf("    r.GET(\"/%s/:id\", %sHandler.Get%s)\n", tag, lower, title))
+	routes.WriteString(fmt.Sprintf("    r.GET(\"/%s\", %sHandler.List%ss)\n", tag, lower, title))
+	routes.WriteString(fmt.Sprintf("    r.PUT(\"/%s/:id\", %sHandler.Update%s)\n", tag, lower, title))
+	routes.WriteString(fmt.Sprintf("    r.DELETE(\"/%s/:id\", %sHandler.Delete%s)\n", tag, lower, title))
+
+	returnAnchor := "    return r\n}"
+	if !strings.Contains(src, returnAnchor) {
+		return fmt.Errorf("could not locate InitRouter's return statement in internal/router/main_router.go")
+	}
+	src = strings.Replace(src, returnAnchor, routes.String()+"\n"+returnAnchor, 1)
+
+	return os.WriteFile(path, []byte(src), 0644)
+}
+
+// injectCrudServer wires cmd/server.go to open the database, build the
+// generated handler via its Wire injector, and pass it into InitRouter.
+// The Initialize{{Title}}Handler symbol only exists once 'shiroha wire'
+// has generated wire_gen.go, mirroring how the docs import only resolves
+// after 'swag init' has run.
+func injectCrudServer(projectDir, moduleName, title, lower string) error {
+	path := filepath.Join(projectDir, "cmd", "server.go")
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read cmd/server.go: %w", err)
+	}
+	src := string(content)
+
+	importAnchor := fmt.Sprintf("\"%s/internal/router\"\n\n)", moduleName)
+	if !strings.Contains(src, importAnchor) {
+		return fmt.Errorf("could not locate internal/router import in cmd/server.go")
+	}
+	src = strings.Replace(src, importAnchor,
+		fmt.Sprintf("\"%s/internal/router\"\n    \"%s/internal/database\"\n\n)", moduleName, moduleName), 1)
+
+	initAnchor := "    r := router.InitRouter()\n"
+	if !strings.Contains(src, initAnchor) {
+		return fmt.Errorf("could not locate router.InitRouter() call in cmd/server.go")
+	}
+
+	var wiring strings.Builder
+	wiring.WriteString("    db, err := database.InitDB()\n")
+	wiring.WriteString("    if err != nil {\n")
+	wiring.WriteString("       log.Fatalf(\"failed to init database: %v\", err)\n")
+	wiring.WriteString("    }\n\n")
+	wiring.WriteString(fmt.Sprintf("    %sHandler, err := Initialize%sHandler(db)\n", lower, title))
+	wiring.WriteString("    if err != nil {\n")
+	wiring.WriteString(fmt.Sprintf("       log.Fatalf(\"failed to wire %s handler: %%v\", err)\n", title))
+	wiring.WriteString("    }\n\n")
+	wiring.WriteString(fmt.Sprintf("    r := router.InitRouter(%sHandler)\n", lower))
+
+	src = strings.Replace(src, initAnchor, wiring.String(), 1)
+
+	return os.WriteFile(path, []byte(src), 0644)
+}
+
+const crudModelTemplate = `package model
+
+import (
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// {{Title}} is the persisted representation of a {{Title}}.
+type {{Title}} struct {
+    ID        uint           ` + "`gorm:\"primaryKey\" json:\"id\"`" + `
+    Name      string         ` + "`gorm:\"size:255;not null\" json:\"name\"`" + `
+    CreatedAt time.Time      ` + "`json:\"created_at\"`" + `
+    UpdatedAt time.Time      ` + "`json:\"updated_at\"`" + `
+    DeletedAt gorm.DeletedAt ` + "`gorm:\"index\" json:\"-\"`" + `
+}
+`
+
+const crudRepositoryTemplate = `package repository
+
+import (
+    "gorm.io/gorm"
+
+    "{{Module}}/internal/model"
+)
+
+// {{Title}}Repository persists and retrieves model.{{Title}} records.
+type {{Title}}Repository interface {
+    Create(entity *model.{{Title}}) error
+    GetByID(id uint) (*model.{{Title}}, error)
+    List() ([]model.{{Title}}, error)
+    Update(entity *model.{{Title}}) error
+    Delete(id uint) error
+}
+
+type {{title}}Repository struct {
+    db *gorm.DB
+}
+
+// New{{Title}}Repository builds a gorm-backed {{Title}}Repository.
+func New{{Title}}Repository(db *gorm.DB) {{Title}}Repository {
+    return &{{title}}Repository{db: db}
+}
+
+func (r *{{title}}Repository) Create(entity *model.{{Title}}) error {
+    return r.db.Create(entity).Error
+}
+
+func (r *{{title}}Repository) GetByID(id uint) (*model.{{Title}}, error) {
+    var entity model.{{Title}}
+    if err := r.db.First(&entity, id).Error; err != nil {
+       return nil, err
+    }
+    return &entity, nil
+}
+
+func (r *{{title}}Repository) List() ([]model.{{Title}}, error) {
+    var entities []model.{{Title}}
+    if err := r.db.Find(&entities).Error; err != nil {
+       return nil, err
+    }
+    return entities, nil
+}
+
+func (r *{{title}}Repository) Update(entity *model.{{Title}}) error {
+    return r.db.Save(entity).Error
+}
+
+func (r *{{title}}Repository) Delete(id uint) error {
+    return r.db.Delete(&model.{{Title}}{}, id).Error
+}
+`
+
+const crudServiceTemplate = `package service
+
+import (
+    "{{Module}}/internal/model"
+    "{{Module}}/internal/repository"
+)
+
+// {{Title}}Service contains the business logic for model.{{Title}}.
+type {{Title}}Service interface {
+    Create(entity *model.{{Title}}) error
+    GetByID(id uint) (*model.{{Title}}, error)
+    List() ([]model.{{Title}}, error)
+    Update(entity *model.{{Title}}) error
+    Delete(id uint) error
+}
+
+type {{title}}Service struct {
+    repo repository.{{Title}}Repository
+}
+
+// New{{Title}}Service builds a {{Title}}Service backed by repo.
+func New{{Title}}Service(repo repository.{{Title}}Repository) {{Title}}Service {
+    return &{{title}}Service{repo: repo}
+}
+
+func (s *{{title}}Service) Create(entity *model.{{Title}}) error {
+    return s.repo.Create(entity)
+}
+
+func (s *{{title}}Service) GetByID(id uint) (*model.{{Title}}, error) {
+    return s.repo.GetByID(id)
+}
+
+func (s *{{title}}Service) List() ([]model.{{Title}}, error) {
+    return s.repo.List()
+}
+
+func (s *{{title}}Service) Update(entity *model.{{Title}}) error {
+    return s.repo.Update(entity)
+}
+
+func (s *{{title}}Service) Delete(id uint) error {
+    return s.repo.Delete(id)
+}
+`
+
+const crudHandlerTemplate = `package handler
+
+import (
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+
+    "{{Module}}/internal/model"
+    "{{Module}}/internal/service"
+)
+
+// {{Title}}Handler exposes model.{{Title}} over HTTP.
+type {{Title}}Handler struct {
+    svc service.{{Title}}Service
+}
+
+// New{{Title}}Handler builds a {{Title}}Handler backed by svc.
+func New{{Title}}Handler(svc service.{{Title}}Service) *{{Title}}Handler {
+    return &{{Title}}Handler{svc: svc}
+}
+
+// Create{{Title}}
+// @Summary Create a {{Title}}
+// @Tags {{Title}}
+// @Accept json
+// @Produce json
+// @Param {{title}} body model.{{Title}} true "{{Title}} payload"
+// @Success 201 {object} model.{{Title}}
+// @Router /{{lowertag}} [post]
+func (h *{{Title}}Handler) Create{{Title}}(c *gin.Context) {
+    var entity model.{{Title}}
+    if err := c.ShouldBindJSON(&entity); err != nil {
+       c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+       return
+    }
+
+    if err := h.svc.Create(&entity); err != nil {
+       c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+       return
+    }
+
+    c.JSON(http.StatusCreated, entity)
+}
+
+// Get{{Title}}
+// @Summary Get a {{Title}} by ID
+// @Tags {{Title}}
+// @Produce json
+// @Param id path int true "{{Title}} ID"
+// @Success 200 {object} model.{{Title}}
+// @Router /{{lowertag}}/{id} [get]
+func (h *{{Title}}Handler) Get{{Title}}(c *gin.Context) {
+    id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+    if err != nil {
+       c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+       return
+    }
+
+    entity, err := h.svc.GetByID(uint(id))
+    if err != nil {
+       c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+       return
+    }
+
+    c.JSON(http.StatusOK, entity)
+}
+
+// List{{Title}}s
+// @Summary List {{Title}}s
+// @Tags {{Title}}
+// @Produce json
+// @Success 200 {array} model.{{Title}}
+// @Router /{{lowertag}} [get]
+func (h *{{Title}}Handler) List{{Title}}s(c *gin.Context) {
+    entities, err := h.svc.List()
+    if err != nil {
+       c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+       return
+    }
+
+    c.JSON(http.StatusOK, entities)
+}
+
+// Update{{Title}}
+// @Summary Update a {{Title}}
+// @Tags {{Title}}
+// @Accept json
+// @Produce json
+// @Param id path int true "{{Title}} ID"
+// @Param {{title}} body model.{{Title}} true "{{Title}} payload"
+// @Success 200 {object} model.{{Title}}
+// @Router /{{lowertag}}/{id} [put]
+func (h *{{Title}}Handler) Update{{Title}}(c *gin.Context) {
+    id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+    if err != nil {
+       c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+       return
+    }
+
+    var entity model.{{Title}}
+    if err := c.ShouldBindJSON(&entity); err != nil {
+       c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+       return
+    }
+    entity.ID = uint(id)
+
+    if err := h.svc.Update(&entity); err != nil {
+       c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+       return
+    }
+
+    c.JSON(http.StatusOK, entity)
+}
+
+// Delete{{Title}}
+// @Summary Delete a {{Title}}
+// @Tags {{Title}}
+// @Param id path int true "{{Title}} ID"
+// @Success 204 "No Content"
+// @Router /{{lowertag}}/{id} [delete]
+func (h *{{Title}}Handler) Delete{{Title}}(c *gin.Context) {
+    id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+    if err != nil {
+       c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+       return
+    }
+
+    if err := h.svc.Delete(uint(id)); err != nil {
+       c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+       return
+    }
+
+    c.Status(http.StatusNoContent)
+}
+`
+
+const crudDatabaseTemplate = `package database
+
+import (
+    "gorm.io/driver/sqlite"
+    "gorm.io/gorm"
+
+    "{{Module}}/internal/model"
+)
+
+// InitDB opens the project's SQLite database and migrates its schema,
+// including the {{Title}} model added by 'shiroha new --with-crud'.
+func InitDB() (*gorm.DB, error) {
+    db, err := gorm.Open(sqlite.Open("app.db"), &gorm.Config{})
+    if err != nil {
+       return nil, err
+    }
+
+    if err := db.AutoMigrate(&model.{{Title}}{}); err != nil {
+       return nil, err
+    }
+
+    return db, nil
+}
+`
+
+const crudWireTemplate = `//go:build wireinject
+
+package main
+
+import (
+    "github.com/google/wire"
+    "gorm.io/gorm"
+
+    "{{Module}}/internal/handler"
+    "{{Module}}/internal/repository"
+    "{{Module}}/internal/service"
+)
+
+// {{Title}}ProviderSet wires the repository and service layers for model.{{Title}}.
+var {{Title}}ProviderSet = wire.NewSet(
+    repository.New{{Title}}Repository,
+    service.New{{Title}}Service,
+)
+
+// Initialize{{Title}}Handler builds a fully wired {{Title}}Handler from a *gorm.DB.
+// Run 'shiroha wire' to generate wire_gen.go before 'go build'.
+func Initialize{{Title}}Handler(db *gorm.DB) (*handler.{{Title}}Handler, error) {
+    wire.Build({{Title}}ProviderSet, handler.New{{Title}}Handler)
+    return nil, nil
+}
+`
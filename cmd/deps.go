@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/NexusXian/shiroha/internal/gocommand"
+)
+
+// depsVersionFlag backs 'shiroha new --deps-version'.
+var depsVersionFlag string
+
+// depModules lists, in emission order, the modules the generated project's
+// go.mod requires.
+var depModules = []string{
+	"github.com/gin-gonic/gin",
+	"github.com/spf13/viper",
+	"github.com/swaggo/files",
+	"github.com/swaggo/gin-swagger",
+	"github.com/swaggo/swag",
+}
+
+// crudDepModules lists the additional modules a '--with-crud' vertical
+// slice requires on top of depModules.
+var crudDepModules = []string{
+	"gorm.io/gorm",
+	"gorm.io/driver/sqlite",
+	"github.com/google/wire",
+}
+
+// pinnedDeps is the curated set of known-good versions shiroha ships with,
+// updated by maintainers as the template's dependencies move forward.
+var pinnedDeps = map[string]string{
+	"github.com/gin-gonic/gin":      "v1.9.1",
+	"github.com/spf13/viper":        "v1.18.2",
+	"github.com/swaggo/files":       "v1.0.1",
+	"github.com/swaggo/gin-swagger": "v1.6.0",
+	"github.com/swaggo/swag":        "v1.16.3",
+	"gorm.io/gorm":                  "v1.25.7",
+	"gorm.io/driver/sqlite":         "v1.5.6",
+	"github.com/google/wire":        "v0.6.0",
+}
+
+// resolveDepsVersions resolves the require-block versions for modules
+// according to mode:
+//   - "pinned" (default): shiroha's curated pinnedDeps
+//   - "latest": resolved live via 'go list -m', honoring the caller's
+//     GOPROXY/GONOSUMCHECK/private-module configuration
+//   - anything else: treated as a path to a manifest file (module<space>version
+//     per line) overriding pinnedDeps for the modules it lists
+func resolveDepsVersions(ctx context.Context, mode string, modules []string) (map[string]string, error) {
+	switch mode {
+	case "", "pinned":
+		resolved := make(map[string]string, len(modules))
+		for _, mod := range modules {
+			version, ok := pinnedDeps[mod]
+			if !ok {
+				return nil, fmt.Errorf("no pinned version known for %s", mod)
+			}
+			resolved[mod] = version
+		}
+		return resolved, nil
+	case "latest":
+		resolved := make(map[string]string, len(modules))
+		for _, mod := range modules {
+			version, err := latestModuleVersion(ctx, mod)
+			if err != nil {
+				return nil, err
+			}
+			resolved[mod] = version
+		}
+		return resolved, nil
+	default:
+		return loadDepsManifest(mode, modules)
+	}
+}
+
+// latestModuleVersion resolves mod's latest version via 'go list -m -json
+// <mod>@latest', the same gocommand.Invocation plumbing every other shell-out
+// in shiroha uses, so it picks up the caller's GOPROXY/GONOSUMCHECK/private
+// module configuration instead of hard-coding proxy.golang.org.
+func latestModuleVersion(ctx context.Context, mod string) (string, error) {
+	inv := &gocommand.Invocation{
+		Verb: "go",
+		Args: []string{"list", "-m", "-json", mod + "@latest"},
+		Env:  os.Environ(),
+	}
+
+	stdout, _, err := inv.RunRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve latest version of %s: %w", mod, err)
+	}
+
+	var info struct {
+		Version string `json:"Version"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &info); err != nil {
+		return "", fmt.Errorf("failed to parse 'go list -m' output for %s: %w", mod, err)
+	}
+	if info.Version == "" {
+		return "", fmt.Errorf("'go list -m' did not report a version for %s", mod)
+	}
+
+	return info.Version, nil
+}
+
+// loadDepsManifest reads a "module version" per line file and overlays it
+// on top of pinnedDeps, so a partial manifest only needs to list the
+// modules it wants to pin differently.
+func loadDepsManifest(path string, modules []string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deps manifest %s: %w", path, err)
+	}
+
+	resolved := make(map[string]string, len(modules))
+	for _, mod := range modules {
+		if v, ok := pinnedDeps[mod]; ok {
+			resolved[mod] = v
+		}
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid line in deps manifest %s: %q", path, line)
+		}
+
+		resolved[fields[0]] = fields[1]
+	}
+
+	for _, mod := range modules {
+		if _, ok := resolved[mod]; !ok {
+			return nil, fmt.Errorf("deps manifest %s is missing a version for %s", path, mod)
+		}
+	}
+
+	return resolved, nil
+}
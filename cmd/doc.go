@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"path/filepath"
 
+	"github.com/NexusXian/shiroha/internal/gocommand"
 	"github.com/spf13/cobra"
 )
 
@@ -33,10 +34,12 @@ var docCmd = &cobra.Command{
 		if _, err := exec.LookPath("swag"); err != nil {
 			fmt.Println("⚠️ swag command not found. Installing...")
 
-			install := exec.Command("go", "install", "github.com/swaggo/swag/cmd/swag@latest")
-			install.Stdout = os.Stdout
-			install.Stderr = os.Stderr
-			if err := install.Run(); err != nil {
+			install := &gocommand.Invocation{
+				Verb: "go",
+				Args: []string{"install", "github.com/swaggo/swag/cmd/swag@latest"},
+				Env:  os.Environ(),
+			}
+			if err := install.Run(cmd.Context()); err != nil {
 				return fmt.Errorf("failed to install swag CLI: %w", err)
 			}
 
@@ -44,12 +47,14 @@ var docCmd = &cobra.Command{
 		}
 
 		// Execute swag init
-		generate := exec.Command("swag", "init", "-g", "cmd/server.go", "-o", "docs")
-		generate.Dir = projectRoot
-		generate.Stdout = os.Stdout
-		generate.Stderr = os.Stderr
+		generate := &gocommand.Invocation{
+			Verb:       "swag",
+			Args:       []string{"init", "-g", "cmd/server.go", "-o", "docs"},
+			Env:        os.Environ(),
+			WorkingDir: projectRoot,
+		}
 
-		if err := generate.Run(); err != nil {
+		if err := generate.Run(cmd.Context()); err != nil {
 			return fmt.Errorf("failed to generate documentation: %w", err)
 		}
 
@@ -4,11 +4,11 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
 
+	"github.com/NexusXian/shiroha/internal/gocommand"
 	"github.com/spf13/cobra"
 )
 
@@ -24,7 +24,24 @@ var newCmd = &cobra.Command{
 
 		fmt.Printf("Creating project: %s...\n", projectName)
 
-		if err := createProjectStructure(projectName); err != nil {
+		modules := append([]string{}, depModules...)
+		if withCrudFlag != "" {
+			modules = append(modules, crudDepModules...)
+		}
+
+		deps, err := resolveDepsVersions(cmd.Context(), depsVersionFlag, modules)
+		if err != nil {
+			return fmt.Errorf("failed to resolve dependency versions: %w", err)
+		}
+
+		opts := newProjectOptions{
+			deps:     deps,
+			modules:  modules,
+			withMage: withFlag == "mage",
+			withCrud: withCrudFlag,
+		}
+
+		if err := createProjectStructure(projectName, opts); err != nil {
 			return err
 		}
 
@@ -33,7 +50,10 @@ var newCmd = &cobra.Command{
 		fmt.Printf("cd %s\n", projectName)
 		fmt.Println("go mod tidy")
 		fmt.Println("swag init -g cmd/server.go -o docs")
-		fmt.Println("go run cmd/server.go")
+		if withCrudFlag != "" {
+			fmt.Println("shiroha wire  # required before building: generates the CRUD handler's DI code")
+		}
+		fmt.Println("go run ./cmd")
 
 		reader := bufio.NewReader(os.Stdin)
 		// Updated prompt to include Swagger generation
@@ -50,22 +70,26 @@ var newCmd = &cobra.Command{
 
 			// 1. Run go mod tidy
 			fmt.Println("\nExecuting 'go mod tidy'...")
-			tidyCmd := exec.Command("go", "mod", "tidy")
-			tidyCmd.Dir = projectDir
-			tidyCmd.Stdout = os.Stdout
-			tidyCmd.Stderr = os.Stderr
-			if err := tidyCmd.Run(); err != nil {
+			tidyCmd := &gocommand.Invocation{
+				Verb:       "go",
+				Args:       []string{"mod", "tidy"},
+				Env:        os.Environ(),
+				WorkingDir: projectDir,
+			}
+			if err := tidyCmd.Run(cmd.Context()); err != nil {
 				return fmt.Errorf("failed to run 'go mod tidy': %w", err)
 			}
 			fmt.Println("✅ 'go mod tidy' completed successfully")
 
 			// 2. Run swag init to generate documentation
 			fmt.Println("\nExecuting 'swag init -g cmd/server.go -o docs' to generate API documentation...")
-			swagCmd := exec.Command("swag", "init", "-g", "cmd/server.go", "-o", "docs")
-			swagCmd.Dir = projectDir
-			swagCmd.Stdout = os.Stdout
-			swagCmd.Stderr = os.Stderr
-			if err := swagCmd.Run(); err != nil {
+			swagCmd := &gocommand.Invocation{
+				Verb:       "swag",
+				Args:       []string{"init", "-g", "cmd/server.go", "-o", "docs"},
+				Env:        os.Environ(),
+				WorkingDir: projectDir,
+			}
+			if err := swagCmd.Run(cmd.Context()); err != nil {
 				// Non-fatal error for swag init, often means `swag` tool isn't installed.
 				// We proceed to run the server, but inform the user.
 				fmt.Printf("⚠️ Warning: Failed to run 'swag init'. Please ensure the 'swag' tool is installed (go install github.com/swaggo/swag/cmd/swag@latest): %v\n", err)
@@ -73,13 +97,30 @@ var newCmd = &cobra.Command{
 				fmt.Println("✅ Swagger documentation generated successfully")
 			}
 
+			// 2b. Run wire to generate the CRUD handler's DI code, if requested
+			if withCrudFlag != "" {
+				fmt.Println("\nExecuting 'shiroha wire' to generate CRUD dependency-injection code...")
+				wireCmd := &gocommand.Invocation{
+					Verb:       os.Args[0],
+					Args:       []string{"wire"},
+					Env:        os.Environ(),
+					WorkingDir: projectDir,
+				}
+				if err := wireCmd.Run(cmd.Context()); err != nil {
+					return fmt.Errorf("failed to run 'shiroha wire': %w", err)
+				}
+				fmt.Println("✅ Wire code generated successfully")
+			}
+
 			// 3. Start the project
 			fmt.Println("\nStarting the project...")
-			runCmd := exec.Command("go", "run", "cmd/server.go")
-			runCmd.Dir = projectDir
-			runCmd.Stdout = os.Stdout
-			runCmd.Stderr = os.Stderr
-			if err := runCmd.Run(); err != nil {
+			runCmd := &gocommand.Invocation{
+				Verb:       "go",
+				Args:       []string{"run", "./cmd"},
+				Env:        os.Environ(),
+				WorkingDir: projectDir,
+			}
+			if err := runCmd.Run(cmd.Context()); err != nil {
 				return fmt.Errorf("failed to start project: %w", err)
 			}
 		}
@@ -88,8 +129,17 @@ var newCmd = &cobra.Command{
 	},
 }
 
+// newProjectOptions bundles the toggles 'shiroha new' accepts for
+// customizing the generated project.
+type newProjectOptions struct {
+	deps     map[string]string
+	modules  []string
+	withMage bool
+	withCrud string // entity name for --with-crud, empty to skip
+}
+
 // createProjectStructure creates the folder structure and starter files
-func createProjectStructure(name string) error {
+func createProjectStructure(name string, opts newProjectOptions) error {
 	dirs := []string{
 		"cmd",
 		"internal/database",
@@ -130,19 +180,23 @@ func createProjectStructure(name string) error {
 		goVersion = "1.18"
 	}
 
-	// -------- ✅ go.mod (latest versions) --------
+	// -------- ✅ go.mod (pinned versions) --------
+	var requireLines strings.Builder
+	for _, mod := range opts.modules {
+		version, ok := opts.deps[mod]
+		if !ok {
+			return fmt.Errorf("no resolved version for %s", mod)
+		}
+		requireLines.WriteString(fmt.Sprintf("    %s %s\n", mod, version))
+	}
+
 	goModContent := fmt.Sprintf(`module %s
 
 go %s
 
 require (
-    github.com/gin-gonic/gin latest
-    github.com/spf13/viper latest
-    github.com/swaggo/files latest
-    github.com/swaggo/gin-swagger latest
-    github.com/swaggo/swag latest
-)
-`, name, goVersion)
+%s)
+`, name, goVersion, requireLines.String())
 
 	if err := os.WriteFile(filepath.Join(name, "go.mod"), []byte(goModContent), 0644); err != nil {
 		return fmt.Errorf("failed to write go.mod: %w", err)
@@ -310,9 +364,192 @@ func LoadConfig() {
 		return fmt.Errorf("failed to write config/config.go: %w", err)
 	}
 
+	if opts.withMage {
+		if err := writeMagefile(name); err != nil {
+			return err
+		}
+	}
+
+	if opts.withCrud != "" {
+		if err := writeCrudScaffold(name, opts.withCrud); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// writeMagefile emits a magefile.go (guarded by the "mage" build tag) with
+// build/test/lint/release/swag/docker targets, mirroring the platform
+// matrix shiroha's own 'build' command cross-compiles for.
+func writeMagefile(name string) error {
+	mageContent := fmt.Sprintf(`//go:build mage
+
+package main
+
+import (
+    "archive/tar"
+    "archive/zip"
+    "compress/gzip"
+    "fmt"
+    "io"
+    "os"
+    "path/filepath"
+
+    "github.com/magefile/mage/mg"
+    "github.com/magefile/mage/sh"
+)
+
+// Version is stamped via -X main.Version at release build time.
+var Version = "dev"
+
+var platforms = []struct{ os, arch string }{
+    {"linux", "amd64"},
+    {"linux", "arm64"},
+    {"darwin", "amd64"},
+    {"darwin", "arm64"},
+    {"windows", "amd64"},
+}
+
+// Build compiles the server binary for the host platform.
+func Build() error {
+    return sh.RunV("go", "build", "-o", filepath.Join("bin", "%s"), "./cmd")
+}
+
+// Test runs the project's test suite with the race detector enabled.
+func Test() error {
+    return sh.RunV("go", "test", "-race", "./...")
+}
+
+// Lint runs 'go vet' across the project.
+func Lint() error {
+    return sh.RunV("go", "vet", "./...")
+}
+
+// Swag (re)generates Swagger documentation from annotations.
+func Swag() error {
+    return sh.RunV("swag", "init", "-g", "cmd/server.go", "-o", "docs")
+}
+
+// Docker builds the project's container image, tagged with Version.
+func Docker() error {
+    return sh.RunV("docker", "build", "-t", fmt.Sprintf("%s:%%s", Version), ".")
+}
+
+// Release cross-compiles every supported platform and packages each
+// binary into a tarball (zip on Windows) under dist/.
+func Release() error {
+    mg.Deps(Test)
+
+    distDir := "dist"
+    if err := os.MkdirAll(distDir, 0755); err != nil {
+        return err
+    }
+
+    for _, p := range platforms {
+        outputName := "%s"
+        if p.os == "windows" {
+            outputName += ".exe"
+        }
+
+        binPath := filepath.Join(distDir, outputName)
+        env := map[string]string{"GOOS": p.os, "GOARCH": p.arch}
+        ldflags := fmt.Sprintf("-X main.Version=%%s", Version)
+
+        if err := sh.RunWithV(env, "go", "build", "-ldflags", ldflags, "-o", binPath, "./cmd"); err != nil {
+            return fmt.Errorf("build for %%s/%%s failed: %%w", p.os, p.arch, err)
+        }
+
+        archiveName := fmt.Sprintf("%s-%%s-%%s", p.os, p.arch)
+        if p.os == "windows" {
+            if err := zipArchive(filepath.Join(distDir, archiveName+".zip"), binPath); err != nil {
+                return err
+            }
+        } else {
+            if err := tarGzArchive(filepath.Join(distDir, archiveName+".tar.gz"), binPath); err != nil {
+                return err
+            }
+        }
+
+        if err := os.Remove(binPath); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+func tarGzArchive(archivePath, filePath string) error {
+    out, err := os.Create(archivePath)
+    if err != nil {
+        return err
+    }
+    defer out.Close()
+
+    gz := gzip.NewWriter(out)
+    defer gz.Close()
+
+    tw := tar.NewWriter(gz)
+    defer tw.Close()
+
+    return addFileToTar(tw, filePath)
+}
+
+func addFileToTar(tw *tar.Writer, filePath string) error {
+    f, err := os.Open(filePath)
+    if err != nil {
+        return err
+    }
+    defer f.Close()
+
+    info, err := f.Stat()
+    if err != nil {
+        return err
+    }
+
+    header, err := tar.FileInfoHeader(info, "")
+    if err != nil {
+        return err
+    }
+    header.Name = filepath.Base(filePath)
+
+    if err := tw.WriteHeader(header); err != nil {
+        return err
+    }
+
+    _, err = io.Copy(tw, f)
+    return err
+}
+
+func zipArchive(archivePath, filePath string) error {
+    out, err := os.Create(archivePath)
+    if err != nil {
+        return err
+    }
+    defer out.Close()
+
+    zw := zip.NewWriter(out)
+    defer zw.Close()
+
+    f, err := os.Open(filePath)
+    if err != nil {
+        return err
+    }
+    defer f.Close()
+
+    w, err := zw.Create(filepath.Base(filePath))
+    if err != nil {
+        return err
+    }
+
+    _, err = io.Copy(w, f)
+    return err
+}
+`, name, name, name, name)
+
+	return os.WriteFile(filepath.Join(name, "magefile.go"), []byte(mageContent), 0644)
+}
+
 // compareGoVersion compares two Go version strings (e.g. "1.18" < "1.21" → -1)
 func compareGoVersion(v1, v2 string) int {
 	parse := func(v string) (int, int) {
@@ -344,3 +581,15 @@ func compareGoVersion(v1, v2 string) int {
 	}
 	return 0
 }
+
+func init() {
+	newCmd.Flags().StringVar(&depsVersionFlag, "deps-version", "pinned",
+		"How to resolve dependency versions in the generated go.mod: \"latest\" (query the Go proxy), \"pinned\" (use shiroha's curated versions), or a path to a custom version manifest file")
+	newCmd.Flags().StringVar(&withFlag, "with", "",
+		"Extra scaffolding to emit alongside the project, e.g. \"mage\" for a magefile.go task runner")
+	newCmd.Flags().StringVar(&withCrudFlag, "with-crud", "",
+		"Generate a full model/repository/service/handler vertical slice with Wire DI for the given entity, e.g. \"User\"")
+}
+
+// withFlag backs 'shiroha new --with'.
+var withFlag string
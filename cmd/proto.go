@@ -0,0 +1,418 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/NexusXian/shiroha/internal/gocommand"
+	"github.com/spf13/cobra"
+)
+
+// protoCmd is the parent command for the gRPC/Protobuf workflow.
+var protoCmd = &cobra.Command{
+	Use:   "proto",
+	Short: "Manage the .proto-driven gRPC workflow for the project",
+	Long:  "Scaffold, generate and wire gRPC services defined as .proto files, mirroring the layered Gin project produced by 'shiroha new'.",
+}
+
+// protoNewCmd defines 'shiroha proto new <service>'.
+var protoNewCmd = &cobra.Command{
+	Use:   "new [service-name]",
+	Short: "Scaffold a new .proto file for a gRPC service",
+	Long:  "Create a proto/<service>/v1/<service>.proto file with sane package and go_package options.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		service := args[0]
+
+		projectRoot, err := projectRootDir()
+		if err != nil {
+			return err
+		}
+
+		moduleName, err := readModuleName(projectRoot)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Creating proto service: %s...\n", service)
+
+		if err := scaffoldProtoService(projectRoot, moduleName, service); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ proto/%s/v1/%s.proto generated successfully!\n", service, service)
+		return nil
+	},
+}
+
+// protoGenerateCmd defines 'shiroha proto generate'.
+var protoGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate Go stubs from the project's .proto files",
+	Long:  "Verify (and install if necessary) protoc-gen-go and protoc-gen-go-grpc, then invoke protoc to emit Go stubs into internal/pb.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectRoot, err := projectRootDir()
+		if err != nil {
+			return err
+		}
+
+		if err := ensureProtocToolchain(cmd.Context()); err != nil {
+			return err
+		}
+
+		fmt.Println("📄 Generating gRPC stubs from .proto files...")
+
+		protoFiles, err := findProtoFiles(projectRoot)
+		if err != nil {
+			return err
+		}
+		if len(protoFiles) == 0 {
+			return fmt.Errorf("no .proto files found under proto/, run 'shiroha proto new <service>' first")
+		}
+
+		pbDir := filepath.Join(projectRoot, "internal", "pb")
+		if err := os.MkdirAll(pbDir, 0755); err != nil {
+			return fmt.Errorf("failed to create internal/pb directory: %w", err)
+		}
+
+		for _, proto := range protoFiles {
+			rel, err := filepath.Rel(projectRoot, proto)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("  -> %s\n", rel)
+			generate := &gocommand.Invocation{
+				Verb: "protoc",
+				Args: []string{
+					"-I", "proto",
+					"--go_out=" + pbDir, "--go_opt=paths=source_relative",
+					"--go-grpc_out=" + pbDir, "--go-grpc_opt=paths=source_relative",
+					rel,
+				},
+				Env:        os.Environ(),
+				WorkingDir: projectRoot,
+			}
+
+			if err := generate.Run(cmd.Context()); err != nil {
+				return fmt.Errorf("failed to generate stubs for %s: %w", rel, err)
+			}
+		}
+
+		fmt.Println("✅ gRPC stubs generated in internal/pb")
+		return nil
+	},
+}
+
+// protoInjectCmd defines 'shiroha proto inject'.
+var protoInjectCmd = &cobra.Command{
+	Use:   "inject",
+	Short: "Wire generated services into the Gin router",
+	Long:  "Parse the project's .proto service descriptors and append route registrations to internal/router/main_router.go plus stub handlers in internal/handler/.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectRoot, err := projectRootDir()
+		if err != nil {
+			return err
+		}
+
+		protoFiles, err := findProtoFiles(projectRoot)
+		if err != nil {
+			return err
+		}
+		if len(protoFiles) == 0 {
+			return fmt.Errorf("no .proto files found under proto/, run 'shiroha proto new <service>' first")
+		}
+
+		moduleName, err := readModuleName(projectRoot)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("🔌 Injecting gRPC service routes...")
+
+		for _, proto := range protoFiles {
+			services, err := parseProtoServices(proto)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", proto, err)
+			}
+
+			for _, svc := range services {
+				if err := injectHandlerStub(projectRoot, svc); err != nil {
+					return err
+				}
+				if err := injectRouterRoutes(projectRoot, moduleName, svc); err != nil {
+					return err
+				}
+				fmt.Printf("  -> wired service %s (%d rpc)\n", svc.name, len(svc.rpcs))
+			}
+		}
+
+		fmt.Println("✅ Routes injected into internal/router/main_router.go")
+		return nil
+	},
+}
+
+func init() {
+	protoCmd.AddCommand(protoNewCmd)
+	protoCmd.AddCommand(protoGenerateCmd)
+	protoCmd.AddCommand(protoInjectCmd)
+	rootCmd.AddCommand(protoCmd)
+}
+
+// projectRootDir returns the project root, walking up one level if the
+// current working directory is 'cmd'.
+func projectRootDir() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	if filepath.Base(dir) == "cmd" {
+		dir = filepath.Dir(dir)
+	}
+
+	return dir, nil
+}
+
+// readModuleName extracts the module path from the project's go.mod.
+func readModuleName(projectRoot string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(projectRoot, "go.mod"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module")), nil
+		}
+	}
+
+	return "", fmt.Errorf("module declaration not found in go.mod")
+}
+
+// scaffoldProtoService writes proto/<service>/v1/<service>.proto.
+func scaffoldProtoService(projectRoot, moduleName, service string) error {
+	dir := filepath.Join(projectRoot, "proto", service, "v1")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	content := fmt.Sprintf(`syntax = "proto3";
+
+package %s.v1;
+
+option go_package = "%s/internal/pb/%s/v1;%sv1";
+
+service %sService {
+  rpc Get%s (Get%sRequest) returns (Get%sResponse);
+}
+
+message Get%sRequest {
+  string id = 1;
+}
+
+message Get%sResponse {
+  string id = 1;
+}
+`, service, moduleName, service, service, exportedName(service), exportedName(service), exportedName(service), exportedName(service), exportedName(service), exportedName(service))
+
+	return os.WriteFile(filepath.Join(dir, service+".proto"), []byte(content), 0644)
+}
+
+// ensureProtocToolchain verifies protoc-gen-go and protoc-gen-go-grpc are
+// installed, installing them via 'go install' when missing, the same way
+// docCmd bootstraps 'swag'.
+func ensureProtocToolchain(ctx context.Context) error {
+	tools := map[string]string{
+		"protoc-gen-go":      "google.golang.org/protobuf/cmd/protoc-gen-go@latest",
+		"protoc-gen-go-grpc": "google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest",
+	}
+
+	for bin, pkg := range tools {
+		if _, err := exec.LookPath(bin); err == nil {
+			continue
+		}
+
+		fmt.Printf("⚠️ %s not found. Installing...\n", bin)
+
+		install := &gocommand.Invocation{
+			Verb: "go",
+			Args: []string{"install", pkg},
+			Env:  os.Environ(),
+		}
+		if err := install.Run(ctx); err != nil {
+			return fmt.Errorf("failed to install %s: %w", bin, err)
+		}
+
+		fmt.Printf("✅ %s installed successfully\n", bin)
+	}
+
+	if _, err := exec.LookPath("buf"); err != nil {
+		fmt.Println("ℹ️ buf not found, skipping (optional)")
+	}
+
+	return nil
+}
+
+// findProtoFiles walks proto/ and collects every .proto file.
+func findProtoFiles(projectRoot string) ([]string, error) {
+	protoRoot := filepath.Join(projectRoot, "proto")
+	var files []string
+
+	if _, err := os.Stat(protoRoot); os.IsNotExist(err) {
+		return files, nil
+	}
+
+	err := filepath.Walk(protoRoot, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && strings.HasSuffix(path, ".proto") {
+			files = append(files, path)
+		}
+		return nil
+	})
+
+	return files, err
+}
+
+// protoService is a minimal descriptor parsed out of a .proto file.
+type protoService struct {
+	name string
+	rpcs []string
+}
+
+var (
+	serviceRe = regexp.MustCompile(`service\s+(\w+)\s*{`)
+	rpcRe     = regexp.MustCompile(`rpc\s+(\w+)\s*\(`)
+)
+
+// parseProtoServices scans a .proto file for 'service' and 'rpc' declarations.
+func parseProtoServices(path string) ([]protoService, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var services []protoService
+	lines := strings.Split(string(content), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		m := serviceRe.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+
+		svc := protoService{name: m[1]}
+		for j := i + 1; j < len(lines) && !strings.Contains(lines[j], "}"); j++ {
+			if rm := rpcRe.FindStringSubmatch(lines[j]); rm != nil {
+				svc.rpcs = append(svc.rpcs, rm[1])
+			}
+		}
+		services = append(services, svc)
+	}
+
+	return services, nil
+}
+
+// injectHandlerStub writes internal/handler/<service>_handler.go, or, if it
+// already exists, appends stubs for any of svc.rpcs it's still missing —
+// the case of a new rpc being added to a service's .proto after the first
+// 'shiroha proto generate && shiroha proto inject' run.
+func injectHandlerStub(projectRoot string, svc protoService) error {
+	handlerPath := filepath.Join(projectRoot, "internal", "handler", strings.ToLower(svc.name)+"_handler.go")
+
+	existing, err := os.ReadFile(handlerPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", handlerPath, err)
+	}
+
+	var missing []string
+	for _, rpc := range svc.rpcs {
+		if !strings.Contains(string(existing), "func "+rpc+"(") {
+			missing = append(missing, rpc)
+		}
+	}
+	if existing != nil && len(missing) == 0 {
+		return nil
+	}
+
+	var methods strings.Builder
+	for _, rpc := range missing {
+		methods.WriteString(fmt.Sprintf(`
+// %s handles the gRPC %s.%s rpc over HTTP.
+func %s(c *gin.Context) {
+    c.JSON(200, gin.H{"rpc": %q})
+}
+`, rpc, svc.name, rpc, rpc, rpc))
+	}
+
+	if existing == nil {
+		content := fmt.Sprintf(`package handler
+
+import (
+    "github.com/gin-gonic/gin"
+)
+%s`, methods.String())
+		return os.WriteFile(handlerPath, []byte(content), 0644)
+	}
+
+	return os.WriteFile(handlerPath, append(existing, []byte(methods.String())...), 0644)
+}
+
+// injectRouterRoutes appends route registrations for svc to
+// internal/router/main_router.go, right before InitRouter's return
+// statement, if they aren't already present.
+func injectRouterRoutes(projectRoot, moduleName string, svc protoService) error {
+	routerPath := filepath.Join(projectRoot, "internal", "router", "main_router.go")
+
+	content, err := os.ReadFile(routerPath)
+	if err != nil {
+		return fmt.Errorf("failed to read internal/router/main_router.go: %w", err)
+	}
+
+	routerSrc := string(content)
+
+	var routes strings.Builder
+	for _, rpc := range svc.rpcs {
+		route := fmt.Sprintf("    r.GET(\"/%s/%s\", handler.%s)\n", strings.ToLower(svc.name), strings.ToLower(rpc), rpc)
+		if strings.Contains(routerSrc, route) {
+			continue
+		}
+		routes.WriteString(route)
+	}
+
+	if routes.Len() == 0 {
+		return nil
+	}
+
+	handlerImport := moduleName + "/internal/handler"
+	if !strings.Contains(routerSrc, `"`+handlerImport+`"`) {
+		routerSrc = strings.Replace(routerSrc, "\"github.com/gin-gonic/gin\"",
+			"\"github.com/gin-gonic/gin\"\n\n    \""+handlerImport+"\"", 1)
+	}
+
+	marker := "    return r\n}"
+	if !strings.Contains(routerSrc, marker) {
+		return fmt.Errorf("could not locate InitRouter's return statement in internal/router/main_router.go")
+	}
+
+	injected := strings.Replace(routerSrc, marker, routes.String()+"\n"+marker, 1)
+
+	return os.WriteFile(routerPath, []byte(injected), 0644)
+}
+
+// exportedName converts a lower-case service name to its exported Go form,
+// e.g. "order" -> "Order".
+func exportedName(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
 )
@@ -26,9 +29,15 @@ Usage:
 	},
 }
 
-// Execute serves as the entry point for all commands
+// Execute serves as the entry point for all commands. It wires a
+// context that's canceled on SIGINT/SIGTERM so long-running subcommands
+// (build, run, wire, proto generate) can stop in-flight gocommand
+// invocations on Ctrl-C instead of leaking them past process exit.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		fmt.Println("❌ Error occurred:", err)
 		os.Exit(1)
 	}
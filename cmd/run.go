@@ -3,30 +3,33 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
 
+	"github.com/NexusXian/shiroha/internal/gocommand"
 	"github.com/spf13/cobra"
 )
 
 // runCmd defines the 'shiroha run' command
 var runCmd = &cobra.Command{
 	Use:   "run",
-	Short: "Runs the project's main server (go run cmd/server.go)",
-	Long:  "Executes 'go run cmd/server.go' to start the application server from the project root.",
+	Short: "Runs the project's main server (go run ./cmd)",
+	Long:  "Executes 'go run ./cmd' to start the application server from the project root. Package mode (rather than the single 'cmd/server.go' file) is required so that other files in cmd/, such as a --with-crud project's wire_gen.go, are compiled in too.",
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("Starting project server (go run cmd/server.go)...")
+		fmt.Println("Starting project server (go run ./cmd)...")
 
 		// Check if the project file exists to ensure execution from the project root
 		if _, err := os.Stat("cmd/server.go"); os.IsNotExist(err) {
 			return fmt.Errorf("cmd/server.go not found. Please ensure you are running 'shiroha run' from the project root directory")
 		}
 
-		runCmd := exec.Command("go", "run", "cmd/server.go")
-		runCmd.Stdout = os.Stdout
-		runCmd.Stderr = os.Stderr
+		inv := &gocommand.Invocation{
+			Verb: "go",
+			Args: []string{"run", "./cmd"},
+			Env:  os.Environ(),
+		}
 
-		if err := runCmd.Run(); err != nil {
+		// cmd.Context() carries Ctrl-C cancellation down to the child process.
+		if err := inv.Run(cmd.Context()); err != nil {
 			return fmt.Errorf("failed to start project server: %w", err)
 		}
 
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/NexusXian/shiroha/internal/gocommand"
+	"github.com/spf13/cobra"
+)
+
+// wireCmd generates Wire dependency-injection code (wire_gen.go) from the
+// project's //go:build wireinject injector files, e.g. the ones emitted by
+// 'shiroha new --with-crud'.
+var wireCmd = &cobra.Command{
+	Use:   "wire",
+	Short: "Generate Wire dependency-injection code",
+	Long:  "Install the Wire CLI if needed and run it across the project to turn wireinject-tagged injector files into wire_gen.go.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectRoot, err := projectRootDir()
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("🔌 Generating Wire dependency-injection code...")
+
+		// Ensure the wire CLI exists
+		if _, err := exec.LookPath("wire"); err != nil {
+			fmt.Println("⚠️ wire command not found. Installing...")
+
+			install := &gocommand.Invocation{
+				Verb: "go",
+				Args: []string{"install", "github.com/google/wire/cmd/wire@latest"},
+				Env:  os.Environ(),
+			}
+			if err := install.Run(cmd.Context()); err != nil {
+				return fmt.Errorf("failed to install wire CLI: %w", err)
+			}
+
+			fmt.Println("✅ wire installed successfully")
+		}
+
+		generate := &gocommand.Invocation{
+			Verb:       "wire",
+			Args:       []string{"./..."},
+			Env:        os.Environ(),
+			WorkingDir: projectRoot,
+		}
+
+		if err := generate.Run(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to generate wire code: %w", err)
+		}
+
+		fmt.Printf("✅ Wire code generated in %s\n", filepath.Join(projectRoot, "cmd"))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(wireCmd)
+}
@@ -0,0 +1,122 @@
+// Package gocommand centralizes how shiroha shells out to the `go` tool
+// (and other CLI helpers such as `swag`), so every command gets consistent
+// context cancellation, environment handling, and error reporting instead of
+// each one hand-rolling exec.Command.
+package gocommand
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+var (
+	osStdout io.Writer = os.Stdout
+	osStderr io.Writer = os.Stderr
+)
+
+// Invocation describes a single invocation of an external command. Verb is
+// typically the program name (e.g. "go", "swag"); when BuildFlags is
+// non-empty it is spliced in right after Verb and before Args.
+type Invocation struct {
+	Verb       string
+	Args       []string
+	BuildFlags []string
+	Env        []string
+	WorkingDir string
+}
+
+// Run executes the invocation, streaming stdout/stderr to the caller. It
+// returns a structured Error if the process exits non-zero, or an error
+// wrapping ctx.Err() if the context is cancelled.
+func (i *Invocation) Run(ctx context.Context) error {
+	_, _, err := i.run(ctx, nil, nil)
+	return err
+}
+
+// RunRaw executes the invocation and returns its stdout and stderr captured
+// separately, in addition to the same errors Run returns on failure.
+func (i *Invocation) RunRaw(ctx context.Context) (stdout, stderr *bytes.Buffer, err error) {
+	stdout = &bytes.Buffer{}
+	stderr = &bytes.Buffer{}
+	_, _, err = i.run(ctx, stdout, stderr)
+	return stdout, stderr, err
+}
+
+// run is shared by Run and RunRaw. When out/errOut are nil, the child's
+// stdout/stderr are passed through to the parent process instead of being
+// captured.
+func (i *Invocation) run(ctx context.Context, out, errOut *bytes.Buffer) (*bytes.Buffer, *bytes.Buffer, error) {
+	args := make([]string, 0, len(i.BuildFlags)+len(i.Args)+1)
+	args = append(args, i.Verb)
+	args = append(args, i.BuildFlags...)
+	args = append(args, i.Args...)
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Env = i.Env
+	cmd.Dir = i.WorkingDir
+
+	stderrTail := &bytes.Buffer{}
+	if errOut != nil {
+		cmd.Stderr = io.MultiWriter(errOut, stderrTail)
+	} else {
+		cmd.Stderr = io.MultiWriter(osStderr, stderrTail)
+	}
+
+	if out != nil {
+		cmd.Stdout = out
+	} else {
+		cmd.Stdout = osStdout
+	}
+
+	runErr := cmd.Run()
+
+	if ctx.Err() != nil {
+		return out, errOut, fmt.Errorf("%s: %w", strings.Join(args, " "), ctx.Err())
+	}
+
+	if runErr != nil {
+		return out, errOut, &Error{
+			Args:     args,
+			ExitCode: exitCode(runErr),
+			Stderr:   stderrTail.String(),
+			err:      runErr,
+		}
+	}
+
+	return out, errOut, nil
+}
+
+// Error is returned when an Invocation's process exits unsuccessfully. It
+// carries enough context (the command line, exit code, and the full stderr
+// output) for callers to surface a useful message without re-running the
+// command.
+type Error struct {
+	Args     []string
+	ExitCode int
+	Stderr   string
+	err      error
+}
+
+func (e *Error) Error() string {
+	tail := strings.TrimSpace(e.Stderr)
+	if tail == "" {
+		return fmt.Sprintf("%s: exit code %d", strings.Join(e.Args, " "), e.ExitCode)
+	}
+	return fmt.Sprintf("%s: exit code %d: %s", strings.Join(e.Args, " "), e.ExitCode, tail)
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func exitCode(err error) int {
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
@@ -0,0 +1,67 @@
+package gocommand
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestInvocationRunRawCapturesOutput(t *testing.T) {
+	inv := &Invocation{
+		Verb: "sh",
+		Args: []string{"-c", "echo out; echo err >&2"},
+	}
+
+	stdout, stderr, err := inv.RunRaw(context.Background())
+	if err != nil {
+		t.Fatalf("RunRaw returned unexpected error: %v", err)
+	}
+	if got := stdout.String(); got != "out\n" {
+		t.Errorf("stdout = %q, want %q", got, "out\n")
+	}
+	if got := stderr.String(); got != "err\n" {
+		t.Errorf("stderr = %q, want %q", got, "err\n")
+	}
+}
+
+func TestInvocationRunRawPropagatesExitCodeAndStderr(t *testing.T) {
+	inv := &Invocation{
+		Verb: "sh",
+		Args: []string{"-c", "echo boom >&2; exit 3"},
+	}
+
+	_, _, err := inv.RunRaw(context.Background())
+	if err == nil {
+		t.Fatal("expected an error for a non-zero exit")
+	}
+
+	var invErr *Error
+	if !errors.As(err, &invErr) {
+		t.Fatalf("error = %v, want *Error", err)
+	}
+	if invErr.ExitCode != 3 {
+		t.Errorf("ExitCode = %d, want 3", invErr.ExitCode)
+	}
+	if invErr.Stderr != "boom\n" {
+		t.Errorf("Stderr = %q, want %q", invErr.Stderr, "boom\n")
+	}
+}
+
+func TestInvocationRunCancelledByContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	inv := &Invocation{
+		Verb: "sh",
+		Args: []string{"-c", "sleep 5"},
+	}
+
+	err := inv.Run(ctx)
+	if err == nil {
+		t.Fatal("expected an error when the context is cancelled")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+}